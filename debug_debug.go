@@ -0,0 +1,77 @@
+// Copyright 2018 The gVisor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ilistdebug
+
+package ilist
+
+import "fmt"
+
+// selfer is implemented by *Entry[T, U], and by extension by any *T that
+// embeds Entry[T, U] anonymously. Element[T] implementations that don't go
+// through Entry (e.g. hand-written Linker[*T] methods) aren't tracked:
+// debugLink and debugUnlink silently skip them.
+type selfer[T any, U Element[T]] interface {
+	debugSelf() *Entry[T, U]
+}
+
+// debugLink records that e is now linked into l, and panics if e is
+// already linked into a list (including l itself).
+func debugLink[T any, U Element[T]](l *List[T, U], e *T) {
+	s, ok := any(U(e)).(selfer[T, U])
+	if !ok {
+		return
+	}
+	en := s.debugSelf()
+	if en.owner != nil {
+		panic(fmt.Sprintf("ilist: element %p is already linked into list %p; cannot link into list %p", e, en.owner, l))
+	}
+	en.owner = l
+}
+
+// debugUnlink records that e is no longer linked into l, and panics if e
+// is not linked into any list, or is linked into a list other than l.
+func debugUnlink[T any, U Element[T]](l *List[T, U], e *T) {
+	s, ok := any(U(e)).(selfer[T, U])
+	if !ok {
+		return
+	}
+	en := s.debugSelf()
+	if en.owner == nil {
+		panic(fmt.Sprintf("ilist: element %p is not linked into any list; cannot remove from list %p", e, l))
+	}
+	if en.owner != l {
+		panic(fmt.Sprintf("ilist: element %p is linked into list %p, not list %p", e, en.owner, l))
+	}
+	en.owner = nil
+}
+
+// debugReownAll updates the owner of every element of the list headed by
+// first (following up to n Next links) to l, without the double-link
+// check debugLink performs. It's used by operations that move existing,
+// already-linked elements into a *List value other than the one they were
+// last individually Push/Insert-ed into: PushFrontList and PushBackList
+// (which splice another List's chain in O(1)), MergeSorted (which
+// interleaves two sorted chains), and sync.LockedList.Drain (which copies
+// the List struct itself). first/n are typically l.head/l.len, i.e. "fix
+// up ownership of l's current contents" after such an operation.
+func debugReownAll[T any, U Element[T]](l *List[T, U], first *T, n int) {
+	e := first
+	for i := 0; i < n && e != nil; i++ {
+		if s, ok := any(U(e)).(selfer[T, U]); ok {
+			s.debugSelf().owner = l
+		}
+		e = U(e).Next()
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2018 The gVisor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !ilistdebug
+
+package ilist
+
+// debugLink is a no-op in production builds; see debug_debug.go for the
+// ilistdebug build, which panics on double-inserts.
+//
+//go:nosplit
+func debugLink[T any, U Element[T]](l *List[T, U], e *T) {}
+
+// debugUnlink is a no-op in production builds; see debug_debug.go for the
+// ilistdebug build, which panics on foreign or unlinked removes.
+//
+//go:nosplit
+func debugUnlink[T any, U Element[T]](l *List[T, U], e *T) {}
+
+// debugReownAll is a no-op in production builds, so callers that fix up
+// ownership after a bulk splice (PushFrontList, PushBackList, MergeSorted,
+// sync.LockedList.Drain) pay nothing for it; see debug_debug.go for the
+// ilistdebug build.
+//
+//go:nosplit
+func debugReownAll[T any, U Element[T]](l *List[T, U], first *T, n int) {}
@@ -0,0 +1,73 @@
+// Copyright 2018 The gVisor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ilistdebug
+
+package ilist
+
+// Entry is a default implementation of Linker. Users can add anonymous fields
+// of this type to their structs to make them automatically implement the
+// methods needed by List.
+//
+// Entry requires U to satisfy Element[T], which in turn requires *T to
+// implement Linker[*T]; this is fine for the common case of a single
+// anonymous Entry field, but makes Entry unsuitable for structs that need
+// to belong to more than one list (they'd need *T to forward to one
+// embedded Entry, but not the other). For that case, use MapperEntry and
+// ListWith instead.
+//
+// This is the ilistdebug build of Entry: it carries an extra owner field,
+// used by debugLink/debugUnlink (see debug_debug.go) to detect
+// double-inserts and foreign/unlinked removes. The !ilistdebug build in
+// entry_release.go omits this field, so it costs nothing in production.
+type Entry[T any, U Element[T]] struct {
+	next  *T
+	prev  *T
+	owner *List[T, U]
+}
+
+// Next returns the entry that follows e in the list.
+//
+//go:nosplit
+func (e *Entry[T, U]) Next() *T {
+	return e.next
+}
+
+// Prev returns the entry that precedes e in the list.
+//
+//go:nosplit
+func (e *Entry[T, U]) Prev() *T {
+	return e.prev
+}
+
+// SetNext assigns 'entry' as the entry that follows e in the list.
+//
+//go:nosplit
+func (e *Entry[T, U]) SetNext(elem *T) {
+	e.next = elem
+}
+
+// SetPrev assigns 'entry' as the entry that precedes e in the list.
+//
+//go:nosplit
+func (e *Entry[T, U]) SetPrev(elem *T) {
+	e.prev = elem
+}
+
+// debugSelf returns e itself, letting debugLink/debugUnlink recover the
+// *Entry[T, U] (and so its owner field) from a bare Element[T] when T
+// embeds Entry[T, U] anonymously.
+func (e *Entry[T, U]) debugSelf() *Entry[T, U] {
+	return e
+}
@@ -43,12 +43,26 @@ type Element[T any] interface {
 type List[T any, U Element[T]] struct {
 	head *T
 	tail *T
+	len  int
 }
 
 // Reset resets list l to the empty state.
 func (l *List[T, U]) Reset() {
 	l.head = nil
 	l.tail = nil
+	l.len = 0
+}
+
+// ClaimAll marks every element currently in l as owned by l.
+//
+// This only has an effect in ilistdebug builds (see debug_debug.go); in
+// production builds it is a no-op. It exists for callers that move an entire
+// list's contents into a new *List value by copying the List struct
+// itself, rather than by calling Remove/PushBack on each element (e.g.
+// sync.LockedList.Drain), and so need to repair the ownership recorded on
+// each element to point at the new List value afterwards.
+func (l *List[T, U]) ClaimAll() {
+	debugReownAll(l, l.head, l.len)
 }
 
 // Empty returns true iff the list is empty.
@@ -74,20 +88,16 @@ func (l *List[T, U]) Back() *T {
 
 // Len returns the number of elements in the list.
 //
-// NOTE: This is an O(n) operation.
-//
 //go:nosplit
-func (l *List[T, U]) Len() (count int) {
-	for e := l.Front(); e != nil; e = U(e).Next() {
-		count++
-	}
-	return count
+func (l *List[T, U]) Len() int {
+	return l.len
 }
 
 // PushFront inserts the element e at the front of list l.
 //
 //go:nosplit
 func (l *List[T, U]) PushFront(e *T) {
+	debugLink(l, e)
 	U(e).SetNext(l.head)
 	U(e).SetPrev(nil)
 	if l.head != nil {
@@ -97,10 +107,17 @@ func (l *List[T, U]) PushFront(e *T) {
 	}
 
 	l.head = e
+	l.len++
 }
 
 // PushFrontList inserts list m at the start of list l, emptying m.
 //
+// m must be of the same instantiated List type as l (this is enforced by
+// the compiler, since m's type is List[T, U]); the two lists' lengths are
+// merged as part of the same splice. This remains an O(1) operation in
+// production builds; ilistdebug builds additionally walk the merged list
+// to re-mark m's former elements as owned by l.
+//
 //go:nosplit
 func (l *List[T, U]) PushFrontList(m *List[T, U]) {
 	if l.head == nil {
@@ -112,14 +129,18 @@ func (l *List[T, U]) PushFrontList(m *List[T, U]) {
 
 		l.head = m.head
 	}
+	l.len += m.len
 	m.head = nil
 	m.tail = nil
+	m.len = 0
+	debugReownAll(l, l.head, l.len)
 }
 
 // PushBack inserts the element e at the back of list l.
 //
 //go:nosplit
 func (l *List[T, U]) PushBack(e *T) {
+	debugLink(l, e)
 	U(e).SetNext(nil)
 	U(e).SetPrev(l.tail)
 	if l.tail != nil {
@@ -129,10 +150,17 @@ func (l *List[T, U]) PushBack(e *T) {
 	}
 
 	l.tail = e
+	l.len++
 }
 
 // PushBackList inserts list m at the end of list l, emptying m.
 //
+// m must be of the same instantiated List type as l (this is enforced by
+// the compiler, since m's type is List[T, U]); the two lists' lengths are
+// merged as part of the same splice. This remains an O(1) operation in
+// production builds; ilistdebug builds additionally walk the merged list
+// to re-mark m's former elements as owned by l.
+//
 //go:nosplit
 func (l *List[T, U]) PushBackList(m *List[T, U]) {
 	if l.head == nil {
@@ -144,14 +172,18 @@ func (l *List[T, U]) PushBackList(m *List[T, U]) {
 
 		l.tail = m.tail
 	}
+	l.len += m.len
 	m.head = nil
 	m.tail = nil
+	m.len = 0
+	debugReownAll(l, l.head, l.len)
 }
 
 // InsertAfter inserts e after b.
 //
 //go:nosplit
 func (l *List[T, U]) InsertAfter(b, e *T) {
+	debugLink(l, e)
 	a := U(b).Next()
 
 	U(e).SetNext(a)
@@ -163,12 +195,14 @@ func (l *List[T, U]) InsertAfter(b, e *T) {
 	} else {
 		l.tail = e
 	}
+	l.len++
 }
 
 // InsertBefore inserts e before a.
 //
 //go:nosplit
 func (l *List[T, U]) InsertBefore(a, e *T) {
+	debugLink(l, e)
 	b := U(a).Prev()
 	U(e).SetNext(a)
 	U(e).SetPrev(b)
@@ -179,12 +213,14 @@ func (l *List[T, U]) InsertBefore(a, e *T) {
 	} else {
 		l.head = e
 	}
+	l.len++
 }
 
 // Remove removes e from l.
 //
 //go:nosplit
 func (l *List[T, U]) Remove(e *T) {
+	debugUnlink(l, e)
 	prev := U(e).Prev()
 	next := U(e).Next()
 
@@ -202,12 +238,107 @@ func (l *List[T, U]) Remove(e *T) {
 
 	U(e).SetNext(nil)
 	U(e).SetPrev(nil)
+	l.len--
 }
 
-// Entry is a default implementation of Linker. Users can add anonymous fields
-// of this type to their structs to make them automatically implement the
-// methods needed by List.
-type Entry[T any, U Element[T]] struct {
+// Swap exchanges the positions of a and b in list l, which must both
+// already be in l.
+//
+//go:nosplit
+func (l *List[T, U]) Swap(a, b *T) {
+	if a == b {
+		return
+	}
+	aPrev, bPrev := U(a).Prev(), U(b).Prev()
+	if U(a).Next() == b {
+		l.Remove(a)
+		l.InsertAfter(b, a)
+		return
+	}
+	if U(b).Next() == a {
+		l.Remove(b)
+		l.InsertAfter(a, b)
+		return
+	}
+
+	l.Remove(a)
+	l.Remove(b)
+	if bPrev != nil {
+		l.InsertAfter(bPrev, a)
+	} else {
+		l.PushFront(a)
+	}
+	if aPrev != nil {
+		l.InsertAfter(aPrev, b)
+	} else {
+		l.PushFront(b)
+	}
+}
+
+// MoveToFront moves e, which must already be in l, to the front of l.
+//
+//go:nosplit
+func (l *List[T, U]) MoveToFront(e *T) {
+	if l.head == e {
+		return
+	}
+	l.Remove(e)
+	l.PushFront(e)
+}
+
+// MoveToBack moves e, which must already be in l, to the back of l.
+//
+//go:nosplit
+func (l *List[T, U]) MoveToBack(e *T) {
+	if l.tail == e {
+		return
+	}
+	l.Remove(e)
+	l.PushBack(e)
+}
+
+// MoveBefore moves e, which must already be in l, to its new position
+// immediately before mark, which must also already be in l.
+//
+//go:nosplit
+func (l *List[T, U]) MoveBefore(e, mark *T) {
+	if e == mark {
+		return
+	}
+	l.Remove(e)
+	l.InsertBefore(mark, e)
+}
+
+// MoveAfter moves e, which must already be in l, to its new position
+// immediately after mark, which must also already be in l.
+//
+//go:nosplit
+func (l *List[T, U]) MoveAfter(e, mark *T) {
+	if e == mark {
+		return
+	}
+	l.Remove(e)
+	l.InsertAfter(mark, e)
+}
+
+// TransferBefore removes e from l, which must be e's current list, and
+// inserts it into dst immediately before mark, which must already be in
+// dst. dst may be l itself.
+//
+//go:nosplit
+func (l *List[T, U]) TransferBefore(dst *List[T, U], mark, e *T) {
+	l.Remove(e)
+	dst.InsertBefore(mark, e)
+}
+
+// MapperEntry is the Linker implementation used with ListWith. Unlike
+// Entry[T, U], it is not itself parameterized on an Element[T], so T is
+// never required to implement Linker[*T]; this lets a struct embed more
+// than one MapperEntry[T] (each given a field name of its own) and
+// participate in more than one list at the same time (e.g. a readyLink
+// and a timerLink on the same struct), with an ElementMapper choosing
+// between them per ListWith.
+type MapperEntry[T any] struct {
 	next *T
 	prev *T
 }
@@ -215,27 +346,259 @@ type Entry[T any, U Element[T]] struct {
 // Next returns the entry that follows e in the list.
 //
 //go:nosplit
-func (e *Entry[T, U]) Next() *T {
+func (e *MapperEntry[T]) Next() *T {
 	return e.next
 }
 
 // Prev returns the entry that precedes e in the list.
 //
 //go:nosplit
-func (e *Entry[T, U]) Prev() *T {
+func (e *MapperEntry[T]) Prev() *T {
 	return e.prev
 }
 
 // SetNext assigns 'entry' as the entry that follows e in the list.
 //
 //go:nosplit
-func (e *Entry[T, U]) SetNext(elem *T) {
+func (e *MapperEntry[T]) SetNext(elem *T) {
 	e.next = elem
 }
 
 // SetPrev assigns 'entry' as the entry that precedes e in the list.
 //
 //go:nosplit
-func (e *Entry[T, U]) SetPrev(elem *T) {
+func (e *MapperEntry[T]) SetPrev(elem *T) {
 	e.prev = elem
 }
+
+// ElementMapper projects a *T to the Linker that a ListWith should operate
+// on. Unlike Element, it does not require *T itself to implement Linker,
+// so a single struct may embed more than one MapperEntry and participate
+// in more than one list at the same time (e.g. a readyLink and a
+// timerLink on the same struct):
+//
+//	type Foo struct {
+//		readyLink MapperEntry[Foo]
+//		timerLink MapperEntry[Foo]
+//	}
+//
+//	type fooReadyMapper struct{}
+//
+//	func (fooReadyMapper) LinkerFor(f *Foo) Linker[*Foo] { return &f.readyLink }
+//
+// Implementations are typically zero-sized structs, so that the indirection
+// through LinkerFor compiles away entirely; see ListWith for an example.
+type ElementMapper[T any] interface {
+	// LinkerFor returns the Linker that ListWith should use for elem.
+	LinkerFor(elem *T) Linker[*T]
+}
+
+// ListWith is an intrusive list, like List, except that it locates the
+// Linker for an element via a zero-sized ElementMapper M rather than
+// requiring *T to implement Linker[*T] directly. This allows a single
+// struct to embed multiple MapperEntry fields and be linked into multiple
+// ListWith lists simultaneously.
+//
+// The zero value for ListWith is an empty list ready to use.
+//
+// Unlike List, a bare element's next/prev links aren't directly exposed as
+// T methods (since *T need not implement Linker[*T]), so iteration goes
+// through the list handle via Next/Prev. To iterate over a list (where l
+// is a ListWith):
+//
+//	for e := l.Front(); e != nil; e = l.Next(e) {
+//		// do something with e.
+//	}
+type ListWith[T any, M ElementMapper[T]] struct {
+	head *T
+	tail *T
+}
+
+// linker returns the Linker that this list uses for e.
+//
+//go:nosplit
+func (l *ListWith[T, M]) linker(e *T) Linker[*T] {
+	var m M
+	return m.LinkerFor(e)
+}
+
+// Next returns the entry that follows e in list l, or nil if e is the
+// last entry.
+//
+//go:nosplit
+func (l *ListWith[T, M]) Next(e *T) *T {
+	return l.linker(e).Next()
+}
+
+// Prev returns the entry that precedes e in list l, or nil if e is the
+// first entry.
+//
+//go:nosplit
+func (l *ListWith[T, M]) Prev(e *T) *T {
+	return l.linker(e).Prev()
+}
+
+// Reset resets list l to the empty state.
+func (l *ListWith[T, M]) Reset() {
+	l.head = nil
+	l.tail = nil
+}
+
+// Empty returns true iff the list is empty.
+//
+//go:nosplit
+func (l *ListWith[T, M]) Empty() bool {
+	return l.head == nil
+}
+
+// Front returns the first element of list l or nil.
+//
+//go:nosplit
+func (l *ListWith[T, M]) Front() *T {
+	return l.head
+}
+
+// Back returns the last element of list l or nil.
+//
+//go:nosplit
+func (l *ListWith[T, M]) Back() *T {
+	return l.tail
+}
+
+// Len returns the number of elements in the list.
+//
+// NOTE: This is an O(n) operation.
+//
+//go:nosplit
+func (l *ListWith[T, M]) Len() (count int) {
+	for e := l.Front(); e != nil; e = l.linker(e).Next() {
+		count++
+	}
+	return count
+}
+
+// PushFront inserts the element e at the front of list l.
+//
+//go:nosplit
+func (l *ListWith[T, M]) PushFront(e *T) {
+	l.linker(e).SetNext(l.head)
+	l.linker(e).SetPrev(nil)
+	if l.head != nil {
+		l.linker(l.head).SetPrev(e)
+	} else {
+		l.tail = e
+	}
+
+	l.head = e
+}
+
+// PushFrontList inserts list m at the start of list l, emptying m.
+//
+//go:nosplit
+func (l *ListWith[T, M]) PushFrontList(m *ListWith[T, M]) {
+	if l.head == nil {
+		l.head = m.head
+		l.tail = m.tail
+	} else if m.head != nil {
+		l.linker(l.head).SetPrev(m.tail)
+		l.linker(m.tail).SetNext(l.head)
+
+		l.head = m.head
+	}
+	m.head = nil
+	m.tail = nil
+}
+
+// PushBack inserts the element e at the back of list l.
+//
+//go:nosplit
+func (l *ListWith[T, M]) PushBack(e *T) {
+	l.linker(e).SetNext(nil)
+	l.linker(e).SetPrev(l.tail)
+	if l.tail != nil {
+		l.linker(l.tail).SetNext(e)
+	} else {
+		l.head = e
+	}
+
+	l.tail = e
+}
+
+// PushBackList inserts list m at the end of list l, emptying m.
+//
+//go:nosplit
+func (l *ListWith[T, M]) PushBackList(m *ListWith[T, M]) {
+	if l.head == nil {
+		l.head = m.head
+		l.tail = m.tail
+	} else if m.head != nil {
+		l.linker(l.tail).SetNext(m.head)
+		l.linker(m.head).SetPrev(l.tail)
+
+		l.tail = m.tail
+	}
+	m.head = nil
+	m.tail = nil
+}
+
+// InsertAfter inserts e after b.
+//
+//go:nosplit
+func (l *ListWith[T, M]) InsertAfter(b, e *T) {
+	a := l.linker(b).Next()
+
+	l.linker(e).SetNext(a)
+	l.linker(e).SetPrev(b)
+	l.linker(b).SetNext(e)
+
+	if a != nil {
+		l.linker(a).SetPrev(e)
+	} else {
+		l.tail = e
+	}
+}
+
+// InsertBefore inserts e before a.
+//
+//go:nosplit
+func (l *ListWith[T, M]) InsertBefore(a, e *T) {
+	b := l.linker(a).Prev()
+	l.linker(e).SetNext(a)
+	l.linker(e).SetPrev(b)
+	l.linker(a).SetPrev(e)
+
+	if b != nil {
+		l.linker(b).SetNext(e)
+	} else {
+		l.head = e
+	}
+}
+
+// Remove removes e from l.
+//
+//go:nosplit
+func (l *ListWith[T, M]) Remove(e *T) {
+	prev := l.linker(e).Prev()
+	next := l.linker(e).Next()
+
+	if prev != nil {
+		l.linker(prev).SetNext(next)
+	} else if l.head == e {
+		l.head = next
+	}
+
+	if next != nil {
+		l.linker(next).SetPrev(prev)
+	} else if l.tail == e {
+		l.tail = prev
+	}
+
+	l.linker(e).SetNext(nil)
+	l.linker(e).SetPrev(nil)
+}
+
+// Entry's definition is split across entry_release.go and entry_debug.go,
+// selected by the ilistdebug build tag: the debug variant carries an extra
+// owner field that PushFront, PushBack, InsertAfter, InsertBefore and
+// Remove use to detect double-inserts and foreign/unlinked removes. See
+// debug_release.go and debug_debug.go for the corresponding checks.
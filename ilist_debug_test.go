@@ -0,0 +1,110 @@
+// Copyright 2018 The gVisor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ilistdebug
+
+package ilist_test
+
+import (
+	"testing"
+
+	"github.com/iangudger/ilist"
+)
+
+// assertPanics calls f and fails the test unless f panics.
+func assertPanics(t *testing.T, name string, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("%s: expected a panic, got none", name)
+		}
+	}()
+	f()
+}
+
+func TestListDebugDoubleInsertPanics(t *testing.T) {
+	var l1, l2 ilist.List[element, *element]
+	e := &element{value: 1}
+	l1.PushBack(e)
+	assertPanics(t, "PushBack into second list", func() {
+		l2.PushBack(e)
+	})
+}
+
+func TestListDebugForeignRemovePanics(t *testing.T) {
+	var l1, l2 ilist.List[element, *element]
+	e := &element{value: 1}
+	l1.PushBack(e)
+	assertPanics(t, "Remove from wrong list", func() {
+		l2.Remove(e)
+	})
+}
+
+func TestListDebugUnlinkedRemovePanics(t *testing.T) {
+	var l ilist.List[element, *element]
+	e := &element{value: 1}
+	assertPanics(t, "Remove of never-linked element", func() {
+		l.Remove(e)
+	})
+}
+
+// TestListDebugReownAfterPushFrontList verifies that debugReownAll
+// re-marks m's former elements as owned by l once PushFrontList splices m
+// into l, so that l can later Remove them without debugUnlink mistaking
+// them for still belonging to m.
+func TestListDebugReownAfterPushFrontList(t *testing.T) {
+	var l, m ilist.List[element, *element]
+	e := &element{value: 1}
+	m.PushBack(e)
+
+	l.PushFrontList(&m)
+	l.Remove(e) // must not panic
+}
+
+func TestListDebugReownAfterPushBackList(t *testing.T) {
+	var l, m ilist.List[element, *element]
+	e := &element{value: 1}
+	m.PushBack(e)
+
+	l.PushBackList(&m)
+	l.Remove(e) // must not panic
+}
+
+// TestListDebugReownAfterMergeSorted verifies the same for MergeSorted, in
+// the case where l starts out empty (the early-return path in MergeSorted
+// that also calls debugReownAll).
+func TestListDebugReownAfterMergeSorted(t *testing.T) {
+	var l, m ilist.List[element, *element]
+	e := &element{value: 1}
+	m.PushBack(e)
+
+	ilist.MergeSorted(&l, &m, func(a, b *element) bool { return a.value < b.value })
+	l.Remove(e) // must not panic
+}
+
+func TestListDebugClaimAll(t *testing.T) {
+	var l1, l2 ilist.List[element, *element]
+	e := &element{value: 1}
+	l1.PushBack(e)
+
+	// Simulate moving e's list wholesale into l2 by copying the struct, as
+	// sync.LockedList.Drain does, then repairing ownership with ClaimAll.
+	l2 = l1
+	l2.ClaimAll()
+
+	assertPanics(t, "Remove from original list after ClaimAll", func() {
+		l1.Remove(e)
+	})
+	l2.Remove(e) // must not panic
+}
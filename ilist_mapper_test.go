@@ -0,0 +1,129 @@
+// Copyright 2018 The gVisor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ilist_test
+
+import (
+	"testing"
+
+	"github.com/iangudger/ilist"
+)
+
+// mapperElement participates in two ListWith lists at once via distinct
+// MapperEntry fields, as described on ElementMapper.
+type mapperElement struct {
+	readyLink ilist.MapperEntry[mapperElement]
+	timerLink ilist.MapperEntry[mapperElement]
+	value     int
+}
+
+type readyMapper struct{}
+
+func (readyMapper) LinkerFor(e *mapperElement) ilist.Linker[*mapperElement] { return &e.readyLink }
+
+type timerMapper struct{}
+
+func (timerMapper) LinkerFor(e *mapperElement) ilist.Linker[*mapperElement] { return &e.timerLink }
+
+func readyValues(l *ilist.ListWith[mapperElement, readyMapper]) []int {
+	var got []int
+	for e := l.Front(); e != nil; e = l.Next(e) {
+		got = append(got, e.value)
+	}
+	return got
+}
+
+func timerValues(l *ilist.ListWith[mapperElement, timerMapper]) []int {
+	var got []int
+	for e := l.Front(); e != nil; e = l.Next(e) {
+		got = append(got, e.value)
+	}
+	return got
+}
+
+func TestListWithMultiListMembership(t *testing.T) {
+	var ready ilist.ListWith[mapperElement, readyMapper]
+	var timer ilist.ListWith[mapperElement, timerMapper]
+
+	a := &mapperElement{value: 1}
+	b := &mapperElement{value: 2}
+	c := &mapperElement{value: 3}
+
+	// a and b are on both lists simultaneously, each via its own link.
+	ready.PushBack(a)
+	ready.PushBack(b)
+	timer.PushBack(b)
+	timer.PushFront(a)
+	timer.PushBack(c)
+
+	if got, want := readyValues(&ready), []int{1, 2}; !sameValues(got, want) {
+		t.Fatalf("ready list = %v, want %v", got, want)
+	}
+	if got, want := timerValues(&timer), []int{1, 2, 3}; !sameValues(got, want) {
+		t.Fatalf("timer list = %v, want %v", got, want)
+	}
+
+	// Removing a from timer must not disturb its position on ready.
+	timer.Remove(a)
+	if got, want := timerValues(&timer), []int{2, 3}; !sameValues(got, want) {
+		t.Fatalf("timer list after Remove(a) = %v, want %v", got, want)
+	}
+	if got, want := readyValues(&ready), []int{1, 2}; !sameValues(got, want) {
+		t.Fatalf("ready list after timer.Remove(a) = %v, want %v", got, want)
+	}
+
+	ready.Remove(b)
+	if got, want := readyValues(&ready), []int{1}; !sameValues(got, want) {
+		t.Fatalf("ready list after Remove(b) = %v, want %v", got, want)
+	}
+	if got, want := timerValues(&timer), []int{2, 3}; !sameValues(got, want) {
+		t.Fatalf("timer list after ready.Remove(b) = %v, want %v", got, want)
+	}
+}
+
+func TestListWithPushFrontAndBackList(t *testing.T) {
+	var l1, l2 ilist.ListWith[mapperElement, readyMapper]
+
+	a, b := &mapperElement{value: 1}, &mapperElement{value: 2}
+	c, d := &mapperElement{value: 3}, &mapperElement{value: 4}
+
+	l1.PushBack(a)
+	l1.PushBack(b)
+	l2.PushBack(c)
+	l2.PushBack(d)
+
+	l1.PushBackList(&l2)
+	if got, want := readyValues(&l1), []int{1, 2, 3, 4}; !sameValues(got, want) {
+		t.Fatalf("l1 after PushBackList = %v, want %v", got, want)
+	}
+	if !l2.Empty() {
+		t.Fatalf("l2 should be empty after PushBackList, got %v", readyValues(&l2))
+	}
+
+	e, f := &mapperElement{value: 5}, &mapperElement{value: 6}
+	l2.PushBack(e)
+	l2.PushBack(f)
+	l1.PushFrontList(&l2)
+	if got, want := readyValues(&l1), []int{5, 6, 1, 2, 3, 4}; !sameValues(got, want) {
+		t.Fatalf("l1 after PushFrontList = %v, want %v", got, want)
+	}
+	if !l2.Empty() {
+		t.Fatalf("l2 should be empty after PushFrontList, got %v", readyValues(&l2))
+	}
+
+	l1.Remove(e)
+	if got, want := readyValues(&l1), []int{6, 1, 2, 3, 4}; !sameValues(got, want) {
+		t.Fatalf("l1 after Remove(e) = %v, want %v", got, want)
+	}
+}
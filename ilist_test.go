@@ -0,0 +1,131 @@
+// Copyright 2018 The gVisor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ilist_test
+
+import (
+	"testing"
+
+	"github.com/iangudger/ilist"
+)
+
+type element struct {
+	ilist.Entry[element, *element]
+	value int
+}
+
+func listValues(l *ilist.List[element, *element]) []int {
+	var got []int
+	for e := l.Front(); e != nil; e = e.Next() {
+		got = append(got, e.value)
+	}
+	return got
+}
+
+func sameValues(got, want []int) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestListPushAndRemove(t *testing.T) {
+	var l ilist.List[element, *element]
+	a, b, c := &element{value: 1}, &element{value: 2}, &element{value: 3}
+
+	l.PushBack(a)
+	l.PushBack(b)
+	l.PushFront(c)
+	if got, want := listValues(&l), []int{3, 1, 2}; !sameValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := l.Len(), 3; got != want {
+		t.Errorf("Len() = %d, want %d", got, want)
+	}
+
+	l.Remove(a)
+	if got, want := listValues(&l), []int{3, 2}; !sameValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := l.Len(), 2; got != want {
+		t.Errorf("Len() = %d, want %d", got, want)
+	}
+}
+
+func TestListSwap(t *testing.T) {
+	var l ilist.List[element, *element]
+	a, b, c := &element{value: 1}, &element{value: 2}, &element{value: 3}
+	l.PushBack(a)
+	l.PushBack(b)
+	l.PushBack(c)
+
+	l.Swap(a, c)
+	if got, want := listValues(&l), []int{3, 2, 1}; !sameValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	l.Swap(c, b)
+	if got, want := listValues(&l), []int{2, 3, 1}; !sameValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestListMove(t *testing.T) {
+	var l ilist.List[element, *element]
+	a, b, c := &element{value: 1}, &element{value: 2}, &element{value: 3}
+	l.PushBack(a)
+	l.PushBack(b)
+	l.PushBack(c)
+
+	l.MoveToFront(c)
+	if got, want := listValues(&l), []int{3, 1, 2}; !sameValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	l.MoveToBack(c)
+	if got, want := listValues(&l), []int{1, 2, 3}; !sameValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	l.MoveBefore(c, a)
+	if got, want := listValues(&l), []int{3, 1, 2}; !sameValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	l.MoveAfter(c, b)
+	if got, want := listValues(&l), []int{1, 2, 3}; !sameValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestListTransferBefore(t *testing.T) {
+	var src, dst ilist.List[element, *element]
+	a, b, c := &element{value: 1}, &element{value: 2}, &element{value: 3}
+	src.PushBack(a)
+	src.PushBack(b)
+	dst.PushBack(c)
+
+	src.TransferBefore(&dst, c, b)
+	if got, want := listValues(&src), []int{1}; !sameValues(got, want) {
+		t.Fatalf("src: got %v, want %v", got, want)
+	}
+	if got, want := listValues(&dst), []int{2, 3}; !sameValues(got, want) {
+		t.Fatalf("dst: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,324 @@
+// Copyright 2018 The gVisor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ring provides an intrusive linked list anchored on a sentinel
+// entry, in the style of the standard library's container/list.
+//
+// Unlike ilist.List, a ring.List's Entry knows the list it belongs to, so
+// Entry.Next and Entry.Prev can be called on a bare element and correctly
+// return nil at the ends of the list, without the caller needing to hold
+// the List handle. This also enables MoveToFront, MoveToBack, MoveBefore
+// and MoveAfter. The cost, relative to ilist.List, is two extra pointers per
+// Entry (the owning list's root and the element itself) and an ownership
+// check in MoveToFront, MoveToBack, MoveBefore and MoveAfter. InsertBefore,
+// InsertAfter and Remove do not check ownership.
+//
+// Existing ilist.List users are unaffected: this is a separate package, and
+// ilist.List remains the allocation-free, pointer-only implementation it
+// always was.
+package ring
+
+// Entry is the ring-list node type. Users make a struct eligible for List
+// by embedding a field of this type:
+//
+//	type Foo struct {
+//		ring.Entry[Foo]
+//		// other fields
+//	}
+//
+// The embedding must be anonymous so that Next and Prev are promoted onto
+// *Foo, and so that *Foo satisfies Element[Foo].
+type Entry[T any] struct {
+	next *Entry[T]
+	prev *Entry[T]
+	root *Entry[T]
+	elem *T
+}
+
+// entry returns e itself, and exists so that *T (via an embedded Entry[T])
+// satisfies Element[T].
+func (e *Entry[T]) entry() *Entry[T] {
+	return e
+}
+
+// Next returns the entry that follows e in its list, or nil if e is the
+// last entry, not linked, or e's identity cannot be established (e.g. e is
+// the zero value).
+//
+//go:nosplit
+func (e *Entry[T]) Next() *T {
+	if e.next == nil || e.next == e.root {
+		return nil
+	}
+	return e.next.elem
+}
+
+// Prev returns the entry that precedes e in its list, or nil if e is the
+// first entry or not linked.
+//
+//go:nosplit
+func (e *Entry[T]) Prev() *T {
+	if e.prev == nil || e.prev == e.root {
+		return nil
+	}
+	return e.prev.elem
+}
+
+// linker is implemented by *Entry[T], and is embedded (unexported, so only
+// satisfiable by embedding Entry[T]) in Element.
+type linker[T any] interface {
+	entry() *Entry[T]
+}
+
+// Element is the interface that *T must satisfy to be stored in a List.
+// In practice this means T must embed an anonymous Entry[T] field.
+type Element[T any] interface {
+	*T
+	linker[T]
+}
+
+// List is an intrusive list anchored on a sentinel entry. The zero value
+// for List is an empty list ready to use.
+//
+// To iterate over a list (where l is a List):
+//
+//	for e := l.Front(); e != nil; e = e.Next() {
+//		// do something with e.
+//	}
+//
+// Since Next is a method of the element itself, a walk can also start from
+// any e in the list, without the list handle:
+//
+//	for ; e != nil; e = e.Next() {
+//		// do something with e.
+//	}
+type List[T any, U Element[T]] struct {
+	root Entry[T]
+	len  int
+}
+
+// lazyInit initializes the root entry the first time the list is used, so
+// that the zero value is a usable empty list.
+func (l *List[T, U]) lazyInit() {
+	if l.root.next == nil {
+		l.Init()
+	}
+}
+
+// Init resets l to the empty list, discarding any existing entries without
+// unlinking them.
+func (l *List[T, U]) Init() {
+	l.root.next = &l.root
+	l.root.prev = &l.root
+	l.root.root = &l.root
+	l.len = 0
+}
+
+// Len returns the number of elements in the list.
+//
+//go:nosplit
+func (l *List[T, U]) Len() int {
+	l.lazyInit()
+	return l.len
+}
+
+// Empty returns true iff the list is empty.
+//
+//go:nosplit
+func (l *List[T, U]) Empty() bool {
+	l.lazyInit()
+	return l.len == 0
+}
+
+// Front returns the first element of list l or nil.
+//
+//go:nosplit
+func (l *List[T, U]) Front() *T {
+	l.lazyInit()
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.next.elem
+}
+
+// Back returns the last element of list l or nil.
+//
+//go:nosplit
+func (l *List[T, U]) Back() *T {
+	l.lazyInit()
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.prev.elem
+}
+
+// insert inserts e between at and at.next, and marks e as belonging to l.
+//
+//go:nosplit
+func (l *List[T, U]) insert(e *Entry[T], elem *T, at *Entry[T]) {
+	e.root = &l.root
+	e.elem = elem
+	n := at.next
+	at.next = e
+	e.prev = at
+	e.next = n
+	n.prev = e
+	l.len++
+}
+
+// remove unlinks e from l, which must be e's current list.
+//
+//go:nosplit
+func (l *List[T, U]) remove(e *Entry[T]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.next = nil
+	e.prev = nil
+	e.root = nil
+	e.elem = nil
+	l.len--
+}
+
+// PushFront inserts the element e at the front of list l.
+//
+//go:nosplit
+func (l *List[T, U]) PushFront(e *T) {
+	l.lazyInit()
+	l.insert(U(e).entry(), e, &l.root)
+}
+
+// PushBack inserts the element e at the back of list l.
+//
+//go:nosplit
+func (l *List[T, U]) PushBack(e *T) {
+	l.lazyInit()
+	l.insert(U(e).entry(), e, l.root.prev)
+}
+
+// InsertBefore inserts e before a, which must already be in l.
+//
+//go:nosplit
+func (l *List[T, U]) InsertBefore(a, e *T) {
+	l.insert(U(e).entry(), e, U(a).entry().prev)
+}
+
+// InsertAfter inserts e after b, which must already be in l.
+//
+//go:nosplit
+func (l *List[T, U]) InsertAfter(b, e *T) {
+	l.insert(U(e).entry(), e, U(b).entry())
+}
+
+// Remove removes e from l, which must be e's current list.
+//
+//go:nosplit
+func (l *List[T, U]) Remove(e *T) {
+	l.remove(U(e).entry())
+}
+
+// MoveToFront moves e, which must already be in l, to the front of l.
+//
+//go:nosplit
+func (l *List[T, U]) MoveToFront(e *T) {
+	ee := U(e).entry()
+	if ee.root != &l.root || l.root.next == ee {
+		return
+	}
+	l.remove(ee)
+	l.insert(ee, e, &l.root)
+}
+
+// MoveToBack moves e, which must already be in l, to the back of l.
+//
+//go:nosplit
+func (l *List[T, U]) MoveToBack(e *T) {
+	ee := U(e).entry()
+	if ee.root != &l.root || l.root.prev == ee {
+		return
+	}
+	l.remove(ee)
+	l.insert(ee, e, l.root.prev)
+}
+
+// MoveBefore moves e, which must already be in l, to its new position
+// immediately before mark, which must also already be in l.
+//
+//go:nosplit
+func (l *List[T, U]) MoveBefore(e, mark *T) {
+	ee, me := U(e).entry(), U(mark).entry()
+	if ee.root != &l.root || me.root != &l.root || ee == me {
+		return
+	}
+	l.remove(ee)
+	l.insert(ee, e, me.prev)
+}
+
+// MoveAfter moves e, which must already be in l, to its new position
+// immediately after mark, which must also already be in l.
+//
+//go:nosplit
+func (l *List[T, U]) MoveAfter(e, mark *T) {
+	ee, me := U(e).entry(), U(mark).entry()
+	if ee.root != &l.root || me.root != &l.root || ee == me {
+		return
+	}
+	l.remove(ee)
+	l.insert(ee, e, me)
+}
+
+// PushBackList inserts list m at the end of list l, emptying m.
+//
+// This reassigns the root of every entry in m, so unlike ilist.List's
+// PushBackList, it is O(len(m)) rather than O(1).
+func (l *List[T, U]) PushBackList(m *List[T, U]) {
+	l.lazyInit()
+	m.lazyInit()
+	if m.len == 0 {
+		return
+	}
+	for e := m.root.next; e != &m.root; e = e.next {
+		e.root = &l.root
+	}
+	first, last := m.root.next, m.root.prev
+	at := l.root.prev
+	at.next = first
+	first.prev = at
+	last.next = &l.root
+	l.root.prev = last
+	l.len += m.len
+	m.Init()
+}
+
+// PushFrontList inserts list m at the start of list l, emptying m.
+//
+// This reassigns the root of every entry in m, so unlike ilist.List's
+// PushFrontList, it is O(len(m)) rather than O(1).
+func (l *List[T, U]) PushFrontList(m *List[T, U]) {
+	l.lazyInit()
+	m.lazyInit()
+	if m.len == 0 {
+		return
+	}
+	for e := m.root.next; e != &m.root; e = e.next {
+		e.root = &l.root
+	}
+	first, last := m.root.next, m.root.prev
+	at := l.root.next
+	l.root.next = first
+	first.prev = &l.root
+	last.next = at
+	at.prev = last
+	l.len += m.len
+	m.Init()
+}
@@ -0,0 +1,128 @@
+// Copyright 2018 The gVisor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ring_test
+
+import (
+	"testing"
+
+	"github.com/iangudger/ilist/ring"
+)
+
+type element struct {
+	ring.Entry[element]
+	value int
+}
+
+func listValues(l *ring.List[element, *element]) []int {
+	var got []int
+	for e := l.Front(); e != nil; e = e.Next() {
+		got = append(got, e.value)
+	}
+	return got
+}
+
+func sameValues(got, want []int) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestListPushAndRemove(t *testing.T) {
+	var l ring.List[element, *element]
+	a, b, c := &element{value: 1}, &element{value: 2}, &element{value: 3}
+
+	l.PushBack(a)
+	l.PushBack(b)
+	l.PushFront(c)
+	if got, want := listValues(&l), []int{3, 1, 2}; !sameValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := l.Len(), 3; got != want {
+		t.Errorf("Len() = %d, want %d", got, want)
+	}
+
+	l.Remove(b)
+	if got, want := listValues(&l), []int{3, 1}; !sameValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := a.Next(), (*element)(nil); got != want {
+		t.Errorf("a.Next() = %v, want %v", got, want)
+	}
+	if got, want := a.Prev(), c; got != want {
+		t.Errorf("a.Prev() = %v, want %v", got, want)
+	}
+}
+
+func TestListMove(t *testing.T) {
+	var l ring.List[element, *element]
+	a, b, c := &element{value: 1}, &element{value: 2}, &element{value: 3}
+	l.PushBack(a)
+	l.PushBack(b)
+	l.PushBack(c)
+
+	l.MoveToFront(c)
+	if got, want := listValues(&l), []int{3, 1, 2}; !sameValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	l.MoveToBack(c)
+	if got, want := listValues(&l), []int{1, 2, 3}; !sameValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	l.MoveBefore(c, a)
+	if got, want := listValues(&l), []int{3, 1, 2}; !sameValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	l.MoveAfter(c, b)
+	if got, want := listValues(&l), []int{1, 2, 3}; !sameValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestListPushFrontListAndPushBackList(t *testing.T) {
+	var l, m ring.List[element, *element]
+	a, b, c, d := &element{value: 1}, &element{value: 2}, &element{value: 3}, &element{value: 4}
+	l.PushBack(a)
+	l.PushBack(b)
+	m.PushBack(c)
+	m.PushBack(d)
+
+	l.PushBackList(&m)
+	if got, want := listValues(&l), []int{1, 2, 3, 4}; !sameValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := m.Len(), 0; got != want {
+		t.Errorf("m.Len() = %d, want %d", got, want)
+	}
+	if got, want := d.Next(), (*element)(nil); got != want {
+		t.Errorf("d.Next() = %v, want %v", got, want)
+	}
+
+	e := &element{value: 5}
+	var n ring.List[element, *element]
+	n.PushBack(e)
+	l.PushFrontList(&n)
+	if got, want := listValues(&l), []int{5, 1, 2, 3, 4}; !sameValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2018 The gVisor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ilist
+
+// InsertSorted inserts e into l at the position that keeps l sorted
+// according to less, assuming l is already sorted according to less. This
+// is an O(n) operation.
+//
+//go:nosplit
+func InsertSorted[T any, U Element[T]](l *List[T, U], e *T, less func(a, b *T) bool) {
+	for m := l.Front(); m != nil; m = U(m).Next() {
+		if less(e, m) {
+			l.InsertBefore(m, e)
+			return
+		}
+	}
+	l.PushBack(e)
+}
+
+// SortList sorts l in place according to less, using a bottom-up,
+// allocation-free intrusive merge sort: l is repeatedly split into runs of
+// size 1, 2, 4, ... and adjacent runs are splice-merged in place by
+// rewiring Next/Prev, until a single sorted run remains.
+//
+// The sort is stable: among elements that compare equal, the relative
+// order in l is preserved.
+func SortList[T any, U Element[T]](l *List[T, U], less func(a, b *T) bool) {
+	list := l.Front()
+	if list == nil || U(list).Next() == nil {
+		return
+	}
+
+	var tail *T
+	for insize := 1; ; insize *= 2 {
+		p := list
+		list = nil
+		tail = nil
+		nmerges := 0
+
+		for p != nil {
+			nmerges++
+			q := p
+			psize := 0
+			for i := 0; i < insize && q != nil; i++ {
+				psize++
+				q = U(q).Next()
+			}
+			qsize := insize
+
+			for psize > 0 || (qsize > 0 && q != nil) {
+				var e *T
+				switch {
+				case psize == 0:
+					e, q = q, U(q).Next()
+					qsize--
+				case qsize == 0 || q == nil:
+					e, p = p, U(p).Next()
+					psize--
+				case !less(q, p):
+					e, p = p, U(p).Next()
+					psize--
+				default:
+					e, q = q, U(q).Next()
+					qsize--
+				}
+
+				U(e).SetPrev(tail)
+				if tail != nil {
+					U(tail).SetNext(e)
+				} else {
+					list = e
+				}
+				tail = e
+			}
+
+			p = q
+		}
+		U(tail).SetNext(nil)
+
+		if nmerges <= 1 {
+			break
+		}
+	}
+
+	l.head = list
+	l.tail = tail
+}
+
+// MergeSorted merges m into l, emptying m, assuming l and m are both
+// already sorted according to less. This is an O(len(l)+len(m))
+// operation; ilistdebug builds additionally walk the merged list to
+// re-mark m's former elements as owned by l.
+//
+// The merge is stable: among elements of l and m that compare equal, the
+// element of l comes first.
+func MergeSorted[T any, U Element[T]](l, m *List[T, U], less func(a, b *T) bool) {
+	if m.head == nil {
+		return
+	}
+	if l.head == nil {
+		l.head, l.tail, l.len = m.head, m.tail, m.len
+		m.head, m.tail, m.len = nil, nil, 0
+		debugReownAll(l, l.head, l.len)
+		return
+	}
+
+	lTail, mTail := l.tail, m.tail
+	p, q := l.head, m.head
+	var head, tail *T
+	for p != nil && q != nil {
+		var e *T
+		if !less(q, p) {
+			e, p = p, U(p).Next()
+		} else {
+			e, q = q, U(q).Next()
+		}
+
+		U(e).SetPrev(tail)
+		if tail != nil {
+			U(tail).SetNext(e)
+		} else {
+			head = e
+		}
+		tail = e
+	}
+
+	if rest := p; rest != nil {
+		U(rest).SetPrev(tail)
+		U(tail).SetNext(rest)
+		tail = lTail
+	} else if rest := q; rest != nil {
+		U(rest).SetPrev(tail)
+		U(tail).SetNext(rest)
+		tail = mTail
+	}
+
+	l.head = head
+	l.tail = tail
+	l.len += m.len
+	m.head, m.tail, m.len = nil, nil, 0
+	debugReownAll(l, l.head, l.len)
+}
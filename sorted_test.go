@@ -0,0 +1,114 @@
+// Copyright 2018 The gVisor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ilist_test
+
+import (
+	"testing"
+
+	"github.com/iangudger/ilist"
+)
+
+type rankedElement struct {
+	ilist.Entry[rankedElement, *rankedElement]
+	rank, seq int
+}
+
+func lessRank(a, b *rankedElement) bool {
+	return a.rank < b.rank
+}
+
+func rankedValues(l *ilist.List[rankedElement, *rankedElement]) [][2]int {
+	var got [][2]int
+	for e := l.Front(); e != nil; e = e.Next() {
+		got = append(got, [2]int{e.rank, e.seq})
+	}
+	return got
+}
+
+func sameRankedValues(got, want [][2]int) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestInsertSorted(t *testing.T) {
+	var l ilist.List[rankedElement, *rankedElement]
+	for i, rank := range []int{3, 1, 4, 1, 5} {
+		ilist.InsertSorted(&l, &rankedElement{rank: rank, seq: i}, lessRank)
+	}
+	want := [][2]int{{1, 1}, {1, 3}, {3, 0}, {4, 2}, {5, 4}}
+	if got := rankedValues(&l); !sameRankedValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestSortListStable(t *testing.T) {
+	var l ilist.List[rankedElement, *rankedElement]
+	for i, rank := range []int{3, 1, 4, 1, 5, 9, 2, 6, 1} {
+		l.PushBack(&rankedElement{rank: rank, seq: i})
+	}
+	ilist.SortList(&l, lessRank)
+	want := [][2]int{{1, 1}, {1, 3}, {1, 8}, {2, 6}, {3, 0}, {4, 2}, {5, 4}, {6, 7}, {9, 5}}
+	if got := rankedValues(&l); !sameRankedValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := l.Len(), len(want); got != want {
+		t.Errorf("Len() = %d, want %d", got, want)
+	}
+}
+
+func TestMergeSortedStable(t *testing.T) {
+	var l, m ilist.List[rankedElement, *rankedElement]
+	for i, rank := range []int{1, 3, 5} {
+		l.PushBack(&rankedElement{rank: rank, seq: i})
+	}
+	for i, rank := range []int{1, 2, 5} {
+		m.PushBack(&rankedElement{rank: rank, seq: 10 + i})
+	}
+
+	ilist.MergeSorted(&l, &m, lessRank)
+	want := [][2]int{{1, 0}, {1, 10}, {2, 11}, {3, 1}, {5, 2}, {5, 12}}
+	if got := rankedValues(&l); !sameRankedValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := m.Len(), 0; got != want {
+		t.Errorf("m.Len() = %d, want %d (m should be emptied)", got, want)
+	}
+	if got, want := m.Front(), (*rankedElement)(nil); got != want {
+		t.Errorf("m.Front() = %v, want %v", got, want)
+	}
+}
+
+func TestMergeSortedEmptyDestination(t *testing.T) {
+	var l, m ilist.List[rankedElement, *rankedElement]
+	for i, rank := range []int{2, 1, 3} {
+		m.PushBack(&rankedElement{rank: rank, seq: i})
+	}
+
+	ilist.MergeSorted(&l, &m, lessRank)
+	want := [][2]int{{2, 0}, {1, 1}, {3, 2}}
+	if got := rankedValues(&l); !sameRankedValues(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := m.Len(), 0; got != want {
+		t.Errorf("m.Len() = %d, want %d", got, want)
+	}
+}
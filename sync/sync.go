@@ -0,0 +1,147 @@
+// Copyright 2018 The gVisor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sync provides LockedList, a concurrent-safe wrapper around
+// ilist.List.
+//
+// This addresses the common pattern of callers wrapping an ilist.List in
+// ad-hoc mutex code (e.g. segment queues and endpoint ready lists). The
+// lock-free ilist.List itself is untouched, so hot single-threaded paths
+// still pay nothing for synchronization they don't need.
+package sync
+
+import (
+	"sync"
+
+	"github.com/iangudger/ilist"
+)
+
+// LockedList is an intrusive list, like ilist.List, except that every
+// operation is serialized by an internal Mutex, making it safe to share
+// across goroutines.
+//
+// The zero value for LockedList is an empty list ready to use.
+type LockedList[T any, U ilist.Element[T]] struct {
+	mu sync.Mutex
+	l  ilist.List[T, U]
+}
+
+// Locker returns the Locker that serializes l's operations, for use in
+// constructing a sync.Cond (e.g. for AppendAndSignal) that shares l's lock.
+func (l *LockedList[T, U]) Locker() sync.Locker {
+	return &l.mu
+}
+
+// Empty returns true iff the list is empty.
+func (l *LockedList[T, U]) Empty() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.Empty()
+}
+
+// Len returns the number of elements in the list.
+func (l *LockedList[T, U]) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.Len()
+}
+
+// EmptyLocked is equivalent to Empty, but the caller must already hold l's
+// lock, as when checking a sync.Cond wait condition (see AppendAndSignal).
+func (l *LockedList[T, U]) EmptyLocked() bool {
+	return l.l.Empty()
+}
+
+// PopFrontLocked is equivalent to PopFront, but the caller must already
+// hold l's lock, as when consuming an element after waking from a
+// sync.Cond wait (see AppendAndSignal).
+func (l *LockedList[T, U]) PopFrontLocked() *T {
+	e := l.l.Front()
+	if e != nil {
+		l.l.Remove(e)
+	}
+	return e
+}
+
+// PushFront inserts the element e at the front of list l.
+func (l *LockedList[T, U]) PushFront(e *T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.PushFront(e)
+}
+
+// PushBack inserts the element e at the back of list l.
+func (l *LockedList[T, U]) PushBack(e *T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.PushBack(e)
+}
+
+// Remove removes e from l.
+func (l *LockedList[T, U]) Remove(e *T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.Remove(e)
+}
+
+// PopFront removes and returns the element at the front of l, or returns
+// nil if l is empty.
+func (l *LockedList[T, U]) PopFront() *T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e := l.l.Front()
+	if e != nil {
+		l.l.Remove(e)
+	}
+	return e
+}
+
+// PopBack removes and returns the element at the back of l, or returns nil
+// if l is empty.
+func (l *LockedList[T, U]) PopBack() *T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e := l.l.Back()
+	if e != nil {
+		l.l.Remove(e)
+	}
+	return e
+}
+
+// Drain atomically removes all elements from l and returns them as a new,
+// unlocked ilist.List, leaving l empty. This is an O(1) operation in
+// production builds; ilistdebug builds additionally walk the drained list
+// (see ilist.List.ClaimAll) to re-mark its elements as owned by the
+// returned List value rather than l's internal one.
+func (l *LockedList[T, U]) Drain() *ilist.List[T, U] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	drained := l.l
+	l.l.Reset()
+	drained.ClaimAll()
+	return &drained
+}
+
+// AppendAndSignal pushes e to the back of l and calls cond.Signal(), both
+// while holding l's lock. cond should have been constructed with
+// l.Locker() as its Locker, so that a waiter woken by Signal observes e
+// already linked once it reacquires the lock. A typical waiter holds
+// cond.L across the wait and uses EmptyLocked/PopFrontLocked to check and
+// consume list state without re-entering l's lock.
+func (l *LockedList[T, U]) AppendAndSignal(e *T, cond *sync.Cond) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.PushBack(e)
+	cond.Signal()
+}
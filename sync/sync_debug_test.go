@@ -0,0 +1,36 @@
+// Copyright 2018 The gVisor Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ilistdebug
+
+package sync_test
+
+import (
+	"testing"
+
+	isync "github.com/iangudger/ilist/sync"
+)
+
+// TestLockedListDrainReownsElements verifies that Drain's ClaimAll call
+// re-marks the drained elements as owned by the returned List, so they
+// can be removed from it without debugUnlink mistaking them for still
+// belonging to l's internal List.
+func TestLockedListDrainReownsElements(t *testing.T) {
+	var l isync.LockedList[element, *element]
+	e := &element{value: 1}
+	l.PushBack(e)
+
+	drained := l.Drain()
+	drained.Remove(e) // must not panic
+}